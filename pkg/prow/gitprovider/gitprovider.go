@@ -0,0 +1,54 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gitprovider contains the SCM-agnostic event types that prow
+// plugins are handed, translated from the concrete go-scm webhook payloads.
+package gitprovider
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// GenericCommentEvent is a fully detailed event for comments on either
+// issues or pull requests, in a format that is consistent across the
+// different SCM providers lighthouse supports.
+type GenericCommentEvent struct {
+	IsPR       bool
+	Action     scm.Action
+	Body       string
+	Author     scm.User
+	IssueLink  string
+	Number     int
+	Repo       scm.Repository
+	IssueState string
+}
+
+// PullRequestEvent is a fully detailed event for pull request notifications,
+// in a format that is consistent across the different SCM providers
+// lighthouse supports.
+type PullRequestEvent struct {
+	Action      scm.Action
+	PullRequest scm.PullRequest
+	Repo        scm.Repository
+}
+
+// HasLabel returns true if the label is present in the given list of labels.
+func HasLabel(label string, issueLabels []*scm.Label) bool {
+	for _, l := range issueLabels {
+		if l != nil && l.Name == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package labels holds the well known label names used across the prow
+// plugins so that plugins agree on a single string for a given concept.
+package labels
+
+const (
+	// Help is the name of the label used to mark an issue as needing help
+	// from a contributor.
+	Help = "help wanted"
+	// GoodFirstIssue is the name of the label used to mark an issue as a
+	// good issue for new contributors to pick up.
+	GoodFirstIssue = "good first issue"
+	// Hold is the name of the label used to prevent a pull request from
+	// merging even if it is otherwise mergeable.
+	Hold = "do-not-merge/hold"
+	// NeedsRebase is the name of the label used to mark a pull request as
+	// unmergeable until it is rebased on top of its base branch.
+	NeedsRebase = "needs-rebase"
+)
@@ -0,0 +1,41 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pluginhelp defines the types plugins use to describe themselves
+// so that their documentation can be rendered for maintainers and users.
+package pluginhelp
+
+// Command documents one command a plugin responds to.
+type Command struct {
+	Usage       string
+	Description string
+	Featured    bool
+	WhoCanUse   string
+	Examples    []string
+}
+
+// PluginHelp is the plugin-supplied description of itself, collected by the
+// help provider and rendered into the generated plugin documentation.
+type PluginHelp struct {
+	Description string
+	Config      map[string]string
+	Commands    []Command
+}
+
+// AddCommand records a command the plugin responds to.
+func (h *PluginHelp) AddCommand(c Command) {
+	h.Commands = append(h.Commands, c)
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package checklist contains a plugin which toggles labels based on the
+// GitHub-flavored-markdown task list items checked off in a pull request's
+// body, without requiring contributors to issue any "/command" comment.
+package checklist
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/sirupsen/logrus"
+
+	"github.com/jenkins-x/lighthouse/pkg/prow/gitprovider"
+	"github.com/jenkins-x/lighthouse/pkg/prow/pluginhelp"
+	"github.com/jenkins-x/lighthouse/pkg/prow/plugins"
+)
+
+const pluginName = "checklist"
+
+// taskListItemRe matches a single GitHub-flavored-markdown task list line,
+// capturing its checkbox state and the text that follows it.
+var taskListItemRe = regexp.MustCompile(`(?m)^\s*[-*]\s*\[([ xX])\]\s*(.+?)\s*$`)
+
+func init() {
+	plugins.RegisterPullRequestHandler(pluginName, handlePullRequest, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []string) (*pluginhelp.PluginHelp, error) {
+	pluginHelp := &pluginhelp.PluginHelp{
+		Description: "The checklist plugin applies or removes labels based on which task list items are checked off in a pull request's body, e.g. a '- [x] doc-required' line.",
+	}
+	return pluginHelp, nil
+}
+
+type githubClient interface {
+	AddLabel(owner, repo string, number int, label string, pr bool) error
+	RemoveLabel(owner, repo string, number int, label string, pr bool) error
+	GetIssueLabels(org, repo string, number int, pr bool) ([]*scm.Label, error)
+	CreateComment(owner, repo string, number int, pr bool, comment string) error
+	TeamHasMember(org, teamSlug, user string) (bool, error)
+}
+
+func handlePullRequest(pc plugins.Agent, e gitprovider.PullRequestEvent) error {
+	if e.Action != scm.ActionOpen && e.Action != scm.ActionUpdate && e.Action != scm.ActionSync {
+		return nil
+	}
+	return handle(pc.GitHubClient, pc.Logger, pc.PluginConfig, e.Repo.Namespace, e.Repo.Name, e.PullRequest.Number, e.PullRequest.Body, e.PullRequest.Author.Login)
+}
+
+// handle converges the labels on org/repo#number with the state of the
+// configured checklist items as found in body. author is the login the
+// PR's checked-off task list lines are attributed to, i.e. the PR author,
+// and is who RestrictedLabels is checked against.
+func handle(gc githubClient, log *logrus.Entry, globalCfg *plugins.Configuration, org, repo string, number int, body, author string) error {
+	cfg := globalCfg.Checklist
+	if len(cfg.Items) == 0 {
+		return nil
+	}
+
+	checkedLines := checkedTaskListLines(body)
+
+	issueLabels, err := gc.GetIssueLabels(org, repo, number, true)
+	if err != nil {
+		return fmt.Errorf("failed to get the labels on %s/%s#%d: %v", org, repo, number, err)
+	}
+
+	anyChecked := false
+	for _, item := range cfg.Items {
+		re, err := regexp.Compile(item.Regexp)
+		if err != nil {
+			log.WithError(err).Errorf("Invalid checklist regexp %q.", item.Regexp)
+			continue
+		}
+
+		checked := matchesAny(re, checkedLines)
+		if checked {
+			anyChecked = true
+		}
+
+		hasLabel := gitprovider.HasLabel(item.Label, issueLabels)
+		if checked && !hasLabel {
+			if restricted(gc, log, globalCfg, org, repo, number, item.Label, author) {
+				continue
+			}
+			plugins.EnforceUniqueLabelPrefix(gc, log, globalCfg, org, repo, number, true, item.Label, issueLabels)
+			if err := gc.AddLabel(org, repo, number, item.Label, true); err != nil {
+				log.WithError(err).Errorf("GitHub failed to add the following label: %s", item.Label)
+			}
+		} else if !checked && hasLabel {
+			if restricted(gc, log, globalCfg, org, repo, number, item.Label, author) {
+				continue
+			}
+			if err := gc.RemoveLabel(org, repo, number, item.Label, true); err != nil {
+				log.WithError(err).Errorf("GitHub failed to remove the following label: %s", item.Label)
+			}
+		}
+	}
+
+	if cfg.MissingLabel == "" {
+		return nil
+	}
+
+	hasMissingLabel := gitprovider.HasLabel(cfg.MissingLabel, issueLabels)
+	if !anyChecked && !hasMissingLabel {
+		if !restricted(gc, log, globalCfg, org, repo, number, cfg.MissingLabel, author) {
+			if err := gc.AddLabel(org, repo, number, cfg.MissingLabel, true); err != nil {
+				log.WithError(err).Errorf("GitHub failed to add the following label: %s", cfg.MissingLabel)
+			}
+		}
+	} else if anyChecked && hasMissingLabel {
+		if !restricted(gc, log, globalCfg, org, repo, number, cfg.MissingLabel, author) {
+			if err := gc.RemoveLabel(org, repo, number, cfg.MissingLabel, true); err != nil {
+				log.WithError(err).Errorf("GitHub failed to remove the following label: %s", cfg.MissingLabel)
+			}
+		}
+	}
+
+	return nil
+}
+
+// restricted reports whether author is not allowed to add or remove label
+// per cfg's RestrictedLabels, posting an explanatory comment if so.
+func restricted(gc githubClient, log *logrus.Entry, cfg *plugins.Configuration, org, repo string, number int, label, author string) bool {
+	allowed, err := plugins.IsLabelAllowed(cfg, gc, org, repo, label, author)
+	if err != nil {
+		log.WithError(err).Errorf("Failed to check whether %q can mutate the %q label.", author, label)
+		return false
+	}
+	if allowed {
+		return false
+	}
+
+	msg := fmt.Sprintf("@%s: only specific users and teams may add or remove the `%s` label, so it was not toggled automatically.", author, label)
+	if err := gc.CreateComment(org, repo, number, true, msg); err != nil {
+		log.WithError(err).Errorf("Failed to create comment \"%s\".", msg)
+	}
+	return true
+}
+
+// checkedTaskListLines returns the text of every checked ("- [x]") task
+// list item found in body.
+func checkedTaskListLines(body string) []string {
+	var checked []string
+	for _, match := range taskListItemRe.FindAllStringSubmatch(body, -1) {
+		if strings.EqualFold(match[1], "x") {
+			checked = append(checked, match[2])
+		}
+	}
+	return checked
+}
+
+func matchesAny(re *regexp.Regexp, lines []string) bool {
+	for _, l := range lines {
+		if re.MatchString(l) {
+			return true
+		}
+	}
+	return false
+}
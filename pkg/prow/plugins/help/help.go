@@ -17,6 +17,7 @@ limitations under the License.
 package help
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -81,6 +82,7 @@ type githubClient interface {
 	AddLabel(owner, repo string, number int, label string, pr bool) error
 	RemoveLabel(owner, repo string, number int, label string, pr bool) error
 	GetIssueLabels(org, repo string, number int, pr bool) ([]*scm.Label, error)
+	TeamHasMember(org, teamSlug, user string) (bool, error)
 }
 
 type commentPruner interface {
@@ -92,10 +94,10 @@ func handleGenericComment(pc plugins.Agent, e gitprovider.GenericCommentEvent) e
 	if err != nil {
 		return err
 	}
-	return handle(pc.GitHubClient, pc.Logger, cp, &e)
+	return handle(pc.GitHubClient, pc.Logger, pc.PluginConfig, cp, &e)
 }
 
-func handle(gc githubClient, log *logrus.Entry, cp commentPruner, e *gitprovider.GenericCommentEvent) error {
+func handle(gc githubClient, log *logrus.Entry, cfg *plugins.Configuration, cp commentPruner, e *gitprovider.GenericCommentEvent) error {
 	// Only consider open issues and new comments.
 	if e.IsPR || e.IssueState != "open" || e.Action != scm.ActionCreate {
 		return nil
@@ -115,6 +117,9 @@ func handle(gc githubClient, log *logrus.Entry, cp commentPruner, e *gitprovider
 
 	// If PR has help label and we're asking for it to be removed, remove label
 	if hasHelp && helpRemoveRe.MatchString(e.Body) {
+		if restricted(gc, log, cfg, org, repo, e.Number, e.IsPR, labels.Help, commentAuthor, e.IssueLink, e.Body) {
+			return nil
+		}
 		if err := gc.RemoveLabel(org, repo, e.Number, labels.Help, e.IsPR); err != nil {
 			log.WithError(err).Errorf("GitHub failed to remove the following label: %s", labels.Help)
 		}
@@ -126,7 +131,7 @@ func handle(gc githubClient, log *logrus.Entry, cp commentPruner, e *gitprovider
 		cp.PruneComments(e.IsPR, shouldPrune(log, botName, helpMsgPruneMatch))
 
 		// if it has the good-first-issue label, remove it too
-		if hasGoodFirstIssue {
+		if hasGoodFirstIssue && !restricted(gc, log, cfg, org, repo, e.Number, e.IsPR, labels.GoodFirstIssue, commentAuthor, e.IssueLink, e.Body) {
 			if err := gc.RemoveLabel(org, repo, e.Number, labels.GoodFirstIssue, e.IsPR); err != nil {
 				log.WithError(err).Errorf("GitHub failed to remove the following label: %s", labels.GoodFirstIssue)
 			}
@@ -139,15 +144,20 @@ func handle(gc githubClient, log *logrus.Entry, cp commentPruner, e *gitprovider
 	// If PR does not have the good-first-issue label and we are asking for it to be added,
 	// add both the good-first-issue and help labels
 	if !hasGoodFirstIssue && helpGoodFirstIssueRe.MatchString(e.Body) {
+		if restricted(gc, log, cfg, org, repo, e.Number, e.IsPR, labels.GoodFirstIssue, commentAuthor, e.IssueLink, e.Body) {
+			return nil
+		}
 		if err := gc.CreateComment(org, repo, e.Number, e.IsPR, plugins.FormatResponseRaw(e.Body, e.IssueLink, commentAuthor, goodFirstIssueMsg)); err != nil {
 			log.WithError(err).Errorf("Failed to create comment \"%s\".", goodFirstIssueMsg)
 		}
 
+		plugins.EnforceUniqueLabelPrefix(gc, log, cfg, org, repo, e.Number, e.IsPR, labels.GoodFirstIssue, issueLabels)
 		if err := gc.AddLabel(org, repo, e.Number, labels.GoodFirstIssue, e.IsPR); err != nil {
 			log.WithError(err).Errorf("GitHub failed to add the following label: %s", labels.GoodFirstIssue)
 		}
 
-		if !hasHelp {
+		if !hasHelp && !restricted(gc, log, cfg, org, repo, e.Number, e.IsPR, labels.Help, commentAuthor, e.IssueLink, e.Body) {
+			plugins.EnforceUniqueLabelPrefix(gc, log, cfg, org, repo, e.Number, e.IsPR, labels.Help, issueLabels)
 			if err := gc.AddLabel(org, repo, e.Number, labels.Help, e.IsPR); err != nil {
 				log.WithError(err).Errorf("GitHub failed to add the following label: %s", labels.Help)
 			}
@@ -159,9 +169,13 @@ func handle(gc githubClient, log *logrus.Entry, cp commentPruner, e *gitprovider
 	// If PR does not have the help label and we're asking it to be added,
 	// add the label
 	if !hasHelp && helpRe.MatchString(e.Body) {
+		if restricted(gc, log, cfg, org, repo, e.Number, e.IsPR, labels.Help, commentAuthor, e.IssueLink, e.Body) {
+			return nil
+		}
 		if err := gc.CreateComment(org, repo, e.Number, e.IsPR, plugins.FormatResponseRaw(e.Body, e.IssueLink, commentAuthor, helpMsg)); err != nil {
 			log.WithError(err).Errorf("Failed to create comment \"%s\".", helpMsg)
 		}
+		plugins.EnforceUniqueLabelPrefix(gc, log, cfg, org, repo, e.Number, e.IsPR, labels.Help, issueLabels)
 		if err := gc.AddLabel(org, repo, e.Number, labels.Help, e.IsPR); err != nil {
 			log.WithError(err).Errorf("GitHub failed to add the following label: %s", labels.Help)
 		}
@@ -172,6 +186,9 @@ func handle(gc githubClient, log *logrus.Entry, cp commentPruner, e *gitprovider
 	// If PR has good-first-issue label and we are asking for it to be removed,
 	// remove just the good-first-issue label
 	if hasGoodFirstIssue && helpGoodFirstIssueRemoveRe.MatchString(e.Body) {
+		if restricted(gc, log, cfg, org, repo, e.Number, e.IsPR, labels.GoodFirstIssue, commentAuthor, e.IssueLink, e.Body) {
+			return nil
+		}
 		if err := gc.RemoveLabel(org, repo, e.Number, labels.GoodFirstIssue, e.IsPR); err != nil {
 			log.WithError(err).Errorf("GitHub failed to remove the following label: %s", labels.GoodFirstIssue)
 		}
@@ -188,6 +205,25 @@ func handle(gc githubClient, log *logrus.Entry, cp commentPruner, e *gitprovider
 	return nil
 }
 
+// restricted reports whether commentAuthor is not allowed to add or remove
+// label per cfg's RestrictedLabels, posting an explanatory comment if so.
+func restricted(gc githubClient, log *logrus.Entry, cfg *plugins.Configuration, org, repo string, number int, pr bool, label, commentAuthor, issueLink, body string) bool {
+	allowed, err := plugins.IsLabelAllowed(cfg, gc, org, repo, label, commentAuthor)
+	if err != nil {
+		log.WithError(err).Errorf("Failed to check whether %q can mutate the %q label.", commentAuthor, label)
+		return false
+	}
+	if allowed {
+		return false
+	}
+
+	msg := fmt.Sprintf("only specific users and teams may add or remove the `%s` label.", label)
+	if err := gc.CreateComment(org, repo, number, pr, plugins.FormatResponseRaw(body, issueLink, commentAuthor, msg)); err != nil {
+		log.WithError(err).Errorf("Failed to create comment \"%s\".", msg)
+	}
+	return true
+}
+
 // shouldPrune finds comments left by this plugin.
 func shouldPrune(log *logrus.Entry, botName, msgPruneMatch string) func(*scm.Comment) bool {
 	return func(comment *scm.Comment) bool {
@@ -68,19 +68,21 @@ type githubClient interface {
 	AddLabel(owner, repo string, number int, label string, pr bool) error
 	RemoveLabel(owner, repo string, number int, label string, pr bool) error
 	GetIssueLabels(org, repo string, number int, pr bool) ([]*scm.Label, error)
+	CreateComment(owner, repo string, number int, pr bool, comment string) error
+	TeamHasMember(org, teamSlug, user string) (bool, error)
 }
 
 func handleGenericComment(pc plugins.Agent, e gitprovider.GenericCommentEvent) error {
 	hasLabel := func(label string, labels []*scm.Label) bool {
 		return gitprovider.HasLabel(label, labels)
 	}
-	return handle(pc.GitHubClient, pc.Logger, &e, hasLabel)
+	return handle(pc.GitHubClient, pc.Logger, pc.PluginConfig, &e, hasLabel)
 }
 
 // handle drives the pull request to the desired state. If any user adds
 // a /hold directive, we want to add a label if one does not already exist.
 // If they add /hold cancel, we want to remove the label if it exists.
-func handle(gc githubClient, log *logrus.Entry, e *gitprovider.GenericCommentEvent, f hasLabelFunc) error {
+func handle(gc githubClient, log *logrus.Entry, cfg *plugins.Configuration, e *gitprovider.GenericCommentEvent, f hasLabelFunc) error {
 	if e.Action != scm.ActionCreate {
 		return nil
 	}
@@ -95,6 +97,7 @@ func handle(gc githubClient, log *logrus.Entry, e *gitprovider.GenericCommentEve
 
 	org := e.Repo.Namespace
 	repo := e.Repo.Name
+	commentAuthor := e.Author.Login
 	issueLabels, err := gc.GetIssueLabels(org, repo, e.Number, e.IsPR)
 	if err != nil {
 		return fmt.Errorf("failed to get the labels on %s/%s#%d: %v", org, repo, e.Number, err)
@@ -102,11 +105,33 @@ func handle(gc githubClient, log *logrus.Entry, e *gitprovider.GenericCommentEve
 
 	hasLabel := f(labels.Hold, issueLabels)
 	if hasLabel && !needsLabel {
+		if restricted, err := checkRestricted(gc, cfg, org, repo, e, commentAuthor); err != nil || restricted {
+			return err
+		}
 		log.Infof("Removing %q Label for %s/%s#%d", labels.Hold, org, repo, e.Number)
 		return gc.RemoveLabel(org, repo, e.Number, labels.Hold, e.IsPR)
 	} else if !hasLabel && needsLabel {
+		if restricted, err := checkRestricted(gc, cfg, org, repo, e, commentAuthor); err != nil || restricted {
+			return err
+		}
+		plugins.EnforceUniqueLabelPrefix(gc, log, cfg, org, repo, e.Number, e.IsPR, labels.Hold, issueLabels)
 		log.Infof("Adding %q Label for %s/%s#%d", labels.Hold, org, repo, e.Number)
 		return gc.AddLabel(org, repo, e.Number, labels.Hold, e.IsPR)
 	}
 	return nil
 }
+
+// checkRestricted reports whether commentAuthor is not allowed to mutate
+// the hold label per cfg's RestrictedLabels, posting an explanatory
+// comment if so.
+func checkRestricted(gc githubClient, cfg *plugins.Configuration, org, repo string, e *gitprovider.GenericCommentEvent, commentAuthor string) (bool, error) {
+	allowed, err := plugins.IsLabelAllowed(cfg, gc, org, repo, labels.Hold, commentAuthor)
+	if err != nil {
+		return false, fmt.Errorf("failed to check whether %q can mutate the %q label on %s/%s#%d: %v", commentAuthor, labels.Hold, org, repo, e.Number, err)
+	}
+	if allowed {
+		return false, nil
+	}
+	msg := fmt.Sprintf("only specific users and teams may add or remove the %q Label.", labels.Hold)
+	return true, gc.CreateComment(org, repo, e.Number, e.IsPR, plugins.FormatResponseRaw(e.Body, e.IssueLink, commentAuthor, msg))
+}
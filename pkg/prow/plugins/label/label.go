@@ -0,0 +1,150 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package label contains a plugin which allows the application and removal
+// of arbitrary labels via a generic "/label" and "/remove-label" command.
+package label
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/sirupsen/logrus"
+
+	"github.com/jenkins-x/lighthouse/pkg/prow/gitprovider"
+	"github.com/jenkins-x/lighthouse/pkg/prow/pluginhelp"
+	"github.com/jenkins-x/lighthouse/pkg/prow/plugins"
+)
+
+const pluginName = "label"
+
+var (
+	labelRegex       = regexp.MustCompile(`(?mi)^/label\s*(.*?)\s*$`)
+	removeLabelRegex = regexp.MustCompile(`(?mi)^/remove-label\s*(.*?)\s*$`)
+)
+
+func init() {
+	plugins.RegisterGenericCommentHandler(pluginName, handleGenericComment, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []string) (*pluginhelp.PluginHelp, error) {
+	pluginHelp := &pluginhelp.PluginHelp{
+		Description: "The label plugin adds or removes arbitrary labels in response to the '/label' and '/remove-label' commands.",
+	}
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/[remove-]label <label>, ...",
+		Description: "Adds or removes one or more comma separated labels.",
+		Featured:    false,
+		WhoCanUse:   "Anyone can trigger this command on issues and PRs. Labels listed in RestrictedLabels can only be added or removed by their allowed users and teams.",
+		Examples:    []string{"/label kind/bug", "/remove-label priority/high"},
+	})
+	return pluginHelp, nil
+}
+
+type githubClient interface {
+	AddLabel(owner, repo string, number int, label string, pr bool) error
+	RemoveLabel(owner, repo string, number int, label string, pr bool) error
+	GetIssueLabels(org, repo string, number int, pr bool) ([]*scm.Label, error)
+	CreateComment(owner, repo string, number int, pr bool, comment string) error
+	TeamHasMember(org, teamSlug, user string) (bool, error)
+}
+
+func handleGenericComment(pc plugins.Agent, e gitprovider.GenericCommentEvent) error {
+	return handle(pc.GitHubClient, pc.Logger, pc.PluginConfig, &e)
+}
+
+func handle(gc githubClient, log *logrus.Entry, cfg *plugins.Configuration, e *gitprovider.GenericCommentEvent) error {
+	if e.Action != scm.ActionCreate {
+		return nil
+	}
+
+	toAdd := parseLabels(labelRegex, e.Body)
+	toRemove := parseLabels(removeLabelRegex, e.Body)
+	if len(toAdd) == 0 && len(toRemove) == 0 {
+		return nil
+	}
+
+	org := e.Repo.Namespace
+	repo := e.Repo.Name
+	commentAuthor := e.Author.Login
+
+	issueLabels, err := gc.GetIssueLabels(org, repo, e.Number, e.IsPR)
+	if err != nil {
+		return fmt.Errorf("failed to get the labels on %s/%s#%d: %v", org, repo, e.Number, err)
+	}
+
+	for _, l := range toAdd {
+		if gitprovider.HasLabel(l, issueLabels) {
+			continue
+		}
+		if restricted(gc, log, cfg, org, repo, e.Number, e.IsPR, l, commentAuthor, e.IssueLink, e.Body) {
+			continue
+		}
+		plugins.EnforceUniqueLabelPrefix(gc, log, cfg, org, repo, e.Number, e.IsPR, l, issueLabels)
+		if err := gc.AddLabel(org, repo, e.Number, l, e.IsPR); err != nil {
+			log.WithError(err).Errorf("GitHub failed to add the following label: %s", l)
+		}
+	}
+
+	for _, l := range toRemove {
+		if !gitprovider.HasLabel(l, issueLabels) {
+			continue
+		}
+		if restricted(gc, log, cfg, org, repo, e.Number, e.IsPR, l, commentAuthor, e.IssueLink, e.Body) {
+			continue
+		}
+		if err := gc.RemoveLabel(org, repo, e.Number, l, e.IsPR); err != nil {
+			log.WithError(err).Errorf("GitHub failed to remove the following label: %s", l)
+		}
+	}
+
+	return nil
+}
+
+// restricted reports whether commentAuthor is not allowed to add or remove
+// label per cfg's RestrictedLabels, posting an explanatory comment if so.
+func restricted(gc githubClient, log *logrus.Entry, cfg *plugins.Configuration, org, repo string, number int, pr bool, label, commentAuthor, issueLink, body string) bool {
+	allowed, err := plugins.IsLabelAllowed(cfg, gc, org, repo, label, commentAuthor)
+	if err != nil {
+		log.WithError(err).Errorf("Failed to check whether %q can mutate the %q label.", commentAuthor, label)
+		return false
+	}
+	if allowed {
+		return false
+	}
+
+	msg := fmt.Sprintf("only specific users and teams may add or remove the `%s` label.", label)
+	if err := gc.CreateComment(org, repo, number, pr, plugins.FormatResponseRaw(body, issueLink, commentAuthor, msg)); err != nil {
+		log.WithError(err).Errorf("Failed to create comment \"%s\".", msg)
+	}
+	return true
+}
+
+// parseLabels returns every comma separated label named on a "/label" or
+// "/remove-label" line matched by re.
+func parseLabels(re *regexp.Regexp, body string) []string {
+	var labels []string
+	for _, match := range re.FindAllStringSubmatch(body, -1) {
+		for _, part := range strings.Split(match[1], ",") {
+			if l := strings.ToLower(strings.TrimSpace(part)); l != "" {
+				labels = append(labels, l)
+			}
+		}
+	}
+	return labels
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/sirupsen/logrus"
+)
+
+// LabelPrefix returns the part of a label before its first "/", or the
+// empty string if the label has no prefix.
+func LabelPrefix(label string) string {
+	if i := strings.Index(label, "/"); i >= 0 {
+		return label[:i]
+	}
+	return ""
+}
+
+// labelRemover is the minimal client capability EnforceUniqueLabelPrefix
+// needs, matching the narrower githubClient interfaces individual plugins
+// declare for themselves.
+type labelRemover interface {
+	RemoveLabel(owner, repo string, number int, label string, pr bool) error
+}
+
+// EnforceUniqueLabelPrefix makes sure that, per cfg's UniquePrefixes, at
+// most one label sharing a given prefix is applied at a time. It is meant
+// to be called by plugins right before they add label to an issue or pull
+// request: it removes any other label among issueLabels that shares a
+// prefix with label and that prefix is configured as unique. If label's
+// prefix is not configured as unique, it does nothing.
+func EnforceUniqueLabelPrefix(gc labelRemover, log *logrus.Entry, cfg *Configuration, org, repo string, number int, pr bool, label string, issueLabels []*scm.Label) {
+	prefix := LabelPrefix(label)
+	if prefix == "" || cfg == nil || !stringInSlice(prefix, cfg.Label.UniquePrefixes) {
+		return
+	}
+
+	for _, existing := range issueLabels {
+		if existing == nil || existing.Name == label {
+			continue
+		}
+		if LabelPrefix(existing.Name) != prefix {
+			continue
+		}
+		if err := gc.RemoveLabel(org, repo, number, existing.Name, pr); err != nil {
+			log.WithError(err).Errorf("Failed to remove label %q to enforce unique prefix %q.", existing.Name, prefix)
+		}
+	}
+}
+
+func stringInSlice(s string, list []string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
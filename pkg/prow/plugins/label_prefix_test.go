@@ -0,0 +1,91 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/sirupsen/logrus"
+)
+
+type fakeLabelRemover struct {
+	removed []string
+}
+
+func (f *fakeLabelRemover) RemoveLabel(owner, repo string, number int, label string, pr bool) error {
+	f.removed = append(f.removed, label)
+	return nil
+}
+
+func TestEnforceUniqueLabelPrefix(t *testing.T) {
+	cfg := &Configuration{Label: Label{UniquePrefixes: []string{"priority", "kind"}}}
+	log := logrus.NewEntry(logrus.New())
+
+	cases := []struct {
+		name        string
+		label       string
+		issueLabels []*scm.Label
+		wantRemoved []string
+	}{
+		{
+			name:  "multiple pre-existing labels sharing the prefix are all removed",
+			label: "priority/high",
+			issueLabels: []*scm.Label{
+				{Name: "priority/low"},
+				{Name: "priority/medium"},
+				{Name: "kind/bug"},
+			},
+			wantRemoved: []string{"priority/low", "priority/medium"},
+		},
+		{
+			name:  "prefix not in UniquePrefixes is left alone",
+			label: "area/api",
+			issueLabels: []*scm.Label{
+				{Name: "area/cli"},
+			},
+			wantRemoved: nil,
+		},
+		{
+			name:  "labels without a slash never match a prefix",
+			label: "priority/high",
+			issueLabels: []*scm.Label{
+				{Name: "help wanted"},
+			},
+			wantRemoved: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeLabelRemover{}
+			EnforceUniqueLabelPrefix(fake, log, cfg, "org", "repo", 1, false, tc.label, tc.issueLabels)
+
+			sort.Strings(fake.removed)
+			sort.Strings(tc.wantRemoved)
+			if len(fake.removed) != len(tc.wantRemoved) {
+				t.Fatalf("removed = %v, want %v", fake.removed, tc.wantRemoved)
+			}
+			for i := range fake.removed {
+				if fake.removed[i] != tc.wantRemoved[i] {
+					t.Fatalf("removed = %v, want %v", fake.removed, tc.wantRemoved)
+				}
+			}
+		})
+	}
+}
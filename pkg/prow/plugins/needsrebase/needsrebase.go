@@ -0,0 +1,184 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package needsrebase contains a plugin which keeps the "needs-rebase"
+// label in sync with a pull request's mergeable status, reacting to PR
+// events and to a periodic sweep so that stale pull requests are updated
+// even without new events.
+package needsrebase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/sirupsen/logrus"
+
+	"github.com/jenkins-x/lighthouse/pkg/prow/gitprovider"
+	"github.com/jenkins-x/lighthouse/pkg/prow/labels"
+	"github.com/jenkins-x/lighthouse/pkg/prow/pluginhelp"
+	"github.com/jenkins-x/lighthouse/pkg/prow/plugins"
+)
+
+// PluginName defines this plugin's registered name.
+const PluginName = "needs-rebase"
+
+// defaultMergeableCheckTimeout is used when NeedsRebase.MergeableCheckTimeout
+// is left unset.
+const defaultMergeableCheckTimeout = 5 * time.Minute
+
+const rebaseMsgPruneMatch = "This pull request is not mergeable."
+const rebaseMsg = `
+This pull request is not mergeable. Please rebase it against the base
+branch and push the result; the ` + "`" + labels.NeedsRebase + "`" + ` label will be
+removed automatically once the conflicts are resolved.
+`
+
+func init() {
+	plugins.RegisterPullRequestHandler(PluginName, handlePullRequest, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []string) (*pluginhelp.PluginHelp, error) {
+	pluginHelp := &pluginhelp.PluginHelp{
+		Description: "The needs-rebase plugin adds the '" + labels.NeedsRebase + "' Label to pull requests that are not currently mergeable, and removes it once they are.",
+	}
+	return pluginHelp, nil
+}
+
+type githubClient interface {
+	BotName() (string, error)
+	CreateComment(owner, repo string, number int, pr bool, comment string) error
+	AddLabel(owner, repo string, number int, label string, pr bool) error
+	RemoveLabel(owner, repo string, number int, label string, pr bool) error
+	GetIssueLabels(org, repo string, number int, pr bool) ([]*scm.Label, error)
+	IsMergeable(org, repo string, number int, timeout time.Duration) (bool, error)
+}
+
+type commentPruner interface {
+	PruneComments(pr bool, shouldPrune func(*scm.Comment) bool)
+}
+
+// OpenPR identifies an open pull request for the periodic sweep to
+// reconcile.
+type OpenPR struct {
+	Org, Repo string
+	Number    int
+}
+
+func handlePullRequest(pc plugins.Agent, e gitprovider.PullRequestEvent) error {
+	if e.Action != scm.ActionOpen && e.Action != scm.ActionSync && e.Action != scm.ActionReopen {
+		return nil
+	}
+
+	cp, err := pc.CommentPruner()
+	if err != nil {
+		return err
+	}
+	return handle(pc.GitHubClient, pc.Logger, pc.PluginConfig.NeedsRebase, cp, e.Repo.Namespace, e.Repo.Name, e.PullRequest.Number)
+}
+
+// Sweep reconciles the needs-rebase label on every pull request in openPRs,
+// so that PRs whose mergeable status changed without a new webhook event
+// (e.g. because the base branch moved) still converge.
+func Sweep(pc plugins.Agent, openPRs []OpenPR) []error {
+	var errs []error
+	for _, pr := range openPRs {
+		if !repoEnabled(pc.PluginConfig.NeedsRebase.Repos, pr.Org, pr.Repo) {
+			continue
+		}
+		if pc.GitHubClient == nil {
+			errs = append(errs, fmt.Errorf("agent has no GitHubClient configured"))
+			continue
+		}
+		cp := &plugins.CommentPruner{GitHubClient: pc.GitHubClient, Logger: pc.Logger, Org: pr.Org, Repo: pr.Repo, Number: pr.Number}
+		if err := handle(pc.GitHubClient, pc.Logger, pc.PluginConfig.NeedsRebase, cp, pr.Org, pr.Repo, pr.Number); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// handle drives org/repo#number to the desired needs-rebase state based on
+// its current mergeability.
+func handle(gc githubClient, log *logrus.Entry, cfg plugins.NeedsRebase, cp commentPruner, org, repo string, number int) error {
+	if !repoEnabled(cfg.Repos, org, repo) {
+		return nil
+	}
+
+	issueLabels, err := gc.GetIssueLabels(org, repo, number, true)
+	if err != nil {
+		return fmt.Errorf("failed to get the labels on %s/%s#%d: %v", org, repo, number, err)
+	}
+	hasLabel := gitprovider.HasLabel(labels.NeedsRebase, issueLabels)
+
+	timeout := cfg.MergeableCheckTimeout
+	if timeout <= 0 {
+		timeout = defaultMergeableCheckTimeout
+	}
+	mergeable, err := gc.IsMergeable(org, repo, number, timeout)
+	if err != nil {
+		return fmt.Errorf("failed to check mergeability of %s/%s#%d: %v", org, repo, number, err)
+	}
+
+	if mergeable {
+		if !hasLabel {
+			return nil
+		}
+		log.Infof("Removing %q Label for %s/%s#%d", labels.NeedsRebase, org, repo, number)
+		if err := gc.RemoveLabel(org, repo, number, labels.NeedsRebase, true); err != nil {
+			return err
+		}
+		botName, err := gc.BotName()
+		if err != nil {
+			log.WithError(err).Errorf("Failed to get bot name.")
+			return nil
+		}
+		cp.PruneComments(true, shouldPrune(log, botName))
+		return nil
+	}
+
+	if hasLabel {
+		return nil
+	}
+	log.Infof("Adding %q Label for %s/%s#%d", labels.NeedsRebase, org, repo, number)
+	if err := gc.AddLabel(org, repo, number, labels.NeedsRebase, true); err != nil {
+		return err
+	}
+	return gc.CreateComment(org, repo, number, true, rebaseMsg)
+}
+
+// repoEnabled reports whether org/repo is covered by repos, which may list
+// either a bare "org" or an "org/repo".
+func repoEnabled(repos []string, org, repo string) bool {
+	full := org + "/" + repo
+	for _, r := range repos {
+		if r == org || r == full {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldPrune finds comments left by this plugin.
+func shouldPrune(log *logrus.Entry, botName string) func(*scm.Comment) bool {
+	return func(comment *scm.Comment) bool {
+		if comment.Author.Login != botName {
+			return false
+		}
+		return strings.Contains(comment.Body, rebaseMsgPruneMatch)
+	}
+}
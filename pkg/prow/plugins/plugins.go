@@ -0,0 +1,208 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugins defines the plugin registry that wires webhook events to
+// the individual prow plugins, along with the configuration they share.
+package plugins
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/sirupsen/logrus"
+
+	"github.com/jenkins-x/lighthouse/pkg/prow/gitprovider"
+	"github.com/jenkins-x/lighthouse/pkg/prow/pluginhelp"
+)
+
+// GitHubClient is the subset of SCM client functionality the plugins in this
+// repository rely on. It is named GitHubClient for historical reasons but is
+// backed by go-scm and so works against any supported SCM provider.
+type GitHubClient interface {
+	BotName() (string, error)
+	CreateComment(owner, repo string, number int, pr bool, comment string) error
+	AddLabel(owner, repo string, number int, label string, pr bool) error
+	RemoveLabel(owner, repo string, number int, label string, pr bool) error
+	GetIssueLabels(org, repo string, number int, pr bool) ([]*scm.Label, error)
+	IsCollaborator(org, repo, user string) (bool, error)
+	TeamHasMember(org, teamSlug, user string) (bool, error)
+
+	// ListComments returns every comment on the issue or pull request.
+	ListComments(org, repo string, number int, pr bool) ([]*scm.Comment, error)
+	// DeleteComment deletes the comment identified by id.
+	DeleteComment(org, repo string, number, id int, pr bool) error
+
+	// IsMergeable reports whether a pull request can currently be merged
+	// without conflicts, mapping to go-scm's PR mergeable field.
+	// Implementations retry with exponential backoff while the SCM
+	// reports the mergeable state as still computing, up to timeout
+	// (typically cfg.NeedsRebase.MergeableCheckTimeout).
+	IsMergeable(org, repo string, number int, timeout time.Duration) (bool, error)
+}
+
+// CommentPruner removes comments a plugin previously left once they stop
+// being relevant, e.g. because the label they explained has been removed.
+type CommentPruner struct {
+	GitHubClient GitHubClient
+	Logger       *logrus.Entry
+	Org, Repo    string
+	Number       int
+}
+
+// PruneComments deletes every comment for which shouldPrune returns true.
+func (cp *CommentPruner) PruneComments(pr bool, shouldPrune func(*scm.Comment) bool) {
+	comments, err := cp.GitHubClient.ListComments(cp.Org, cp.Repo, cp.Number, pr)
+	if err != nil {
+		cp.Logger.WithError(err).Errorf("Failed to list comments for %s/%s#%d.", cp.Org, cp.Repo, cp.Number)
+		return
+	}
+	for _, comment := range comments {
+		if !shouldPrune(comment) {
+			continue
+		}
+		if err := cp.GitHubClient.DeleteComment(cp.Org, cp.Repo, cp.Number, comment.ID, pr); err != nil {
+			cp.Logger.WithError(err).Errorf("Failed to delete comment %d from %s/%s#%d.", comment.ID, cp.Org, cp.Repo, cp.Number)
+		}
+	}
+}
+
+// Agent is passed to every plugin handler and bundles the dependencies the
+// handler needs to talk back to the SCM provider and to read its config.
+type Agent struct {
+	GitHubClient GitHubClient
+	Logger       *logrus.Entry
+	PluginConfig *Configuration
+
+	org, repo string
+	number    int
+}
+
+// CommentPruner returns a CommentPruner scoped to the issue or PR the
+// current event is about.
+func (a *Agent) CommentPruner() (*CommentPruner, error) {
+	if a.GitHubClient == nil {
+		return nil, fmt.Errorf("agent has no GitHubClient configured")
+	}
+	return &CommentPruner{GitHubClient: a.GitHubClient, Logger: a.Logger, Org: a.org, Repo: a.repo, Number: a.number}, nil
+}
+
+// GenericCommentHandler handles a comment left on an issue or pull request.
+type GenericCommentHandler func(Agent, gitprovider.GenericCommentEvent) error
+
+// PullRequestHandler handles a pull request notification, e.g. open, edit
+// or synchronize.
+type PullRequestHandler func(Agent, gitprovider.PullRequestEvent) error
+
+// HelpProvider describes a plugin for the generated plugin documentation.
+type HelpProvider func(config *Configuration, enabledRepos []string) (*pluginhelp.PluginHelp, error)
+
+var genericCommentHandlers = map[string]GenericCommentHandler{}
+var pullRequestHandlers = map[string]PullRequestHandler{}
+var helpProviders = map[string]HelpProvider{}
+
+// RegisterGenericCommentHandler registers a plugin's handler for comment
+// events along with its help provider.
+func RegisterGenericCommentHandler(name string, fn GenericCommentHandler, help HelpProvider) {
+	genericCommentHandlers[name] = fn
+	helpProviders[name] = help
+}
+
+// RegisterPullRequestHandler registers a plugin's handler for pull request
+// events along with its help provider.
+func RegisterPullRequestHandler(name string, fn PullRequestHandler, help HelpProvider) {
+	pullRequestHandlers[name] = fn
+	helpProviders[name] = help
+}
+
+// FormatResponseRaw nicely formats a response for one of the commenting
+// plugins, quoting the command that triggered it.
+func FormatResponseRaw(body, link, user, reply string) string {
+	var quoted []string
+	for _, l := range strings.Split(body, "\n") {
+		quoted = append(quoted, ">"+l)
+	}
+	return fmt.Sprintf("@%s: %s\n\nIn response to [this](%s):\n\n%s\n", user, reply, link, strings.Join(quoted, "\n"))
+}
+
+// Configuration is the top level plugin configuration, loaded from the
+// plugins config file and shared by every registered plugin.
+type Configuration struct {
+	// Label holds configuration shared by the plugins that add and remove
+	// labels, such as help, hold and label.
+	Label Label `json:"label,omitempty"`
+
+	// Checklist holds configuration for the checklist plugin.
+	Checklist Checklist `json:"checklist,omitempty"`
+
+	// RestrictedLabels maps a scope ("*", an org, or an "org/repo") to the
+	// labels within that scope whose addition or removal is limited to a
+	// set of users and teams.
+	RestrictedLabels map[string][]RestrictedLabel `json:"restricted_labels,omitempty"`
+
+	// NeedsRebase holds configuration for the needs-rebase plugin.
+	NeedsRebase NeedsRebase `json:"needs_rebase,omitempty"`
+}
+
+// Label holds configuration for the plugins that add and remove labels.
+type Label struct {
+	// AdditionalLabels is a set of additional labels enabled for use
+	// on top of the existing "kind/*", "priority/*" and "area/*" labels.
+	// The label plugin does not read this list: it applies any label
+	// named in a "/label" command.
+	AdditionalLabels []string `json:"additional_labels,omitempty"`
+
+	// UniquePrefixes lists label prefixes (the text before the first "/")
+	// for which only one label may be active on an issue or pull request
+	// at a time, e.g. "priority" so that "priority/high" and
+	// "priority/low" can never both be applied. Adding a label that
+	// shares a prefix in this list removes any other label with that
+	// same prefix.
+	UniquePrefixes []string `json:"unique_prefixes,omitempty"`
+}
+
+// Checklist holds configuration for the checklist plugin, which toggles
+// labels based on which task list items are checked off in a PR's body.
+type Checklist struct {
+	// Items maps a regexp matching the text of a checked task list line
+	// (e.g. "doc-required" would match "- [x] doc-required") to the label
+	// that should be applied while a line matching it is checked.
+	Items []ChecklistItem `json:"items,omitempty"`
+
+	// MissingLabel, if set, is applied whenever none of Items is checked,
+	// and removed again as soon as one becomes checked.
+	MissingLabel string `json:"missing_label,omitempty"`
+}
+
+// ChecklistItem pairs a task list line pattern with the label to toggle.
+type ChecklistItem struct {
+	Regexp string `json:"regexp"`
+	Label  string `json:"label"`
+}
+
+// NeedsRebase holds configuration for the needs-rebase plugin, which keeps
+// a "needs-rebase" label in sync with a pull request's mergeable status.
+type NeedsRebase struct {
+	// Repos lists the orgs ("org") and repos ("org/repo") the plugin is
+	// enabled for. The plugin takes no action on repos not listed here.
+	Repos []string `json:"repos,omitempty"`
+
+	// MergeableCheckTimeout bounds how long a single mergeability check is
+	// allowed to retry while the SCM reports the state as still
+	// computing, before the plugin gives up on that event.
+	MergeableCheckTimeout time.Duration `json:"mergeable_check_timeout,omitempty"`
+}
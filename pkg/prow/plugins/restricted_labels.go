@@ -0,0 +1,80 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import "fmt"
+
+// RestrictedLabel names a label along with the users and teams who are
+// allowed to add or remove it.
+type RestrictedLabel struct {
+	Label        string   `json:"label"`
+	AllowedUsers []string `json:"allowed_users,omitempty"`
+	AllowedTeams []string `json:"allowed_teams,omitempty"`
+}
+
+// teamChecker is the client capability needed to resolve team membership,
+// matching the narrower githubClient interfaces individual plugins declare
+// for themselves.
+type teamChecker interface {
+	TeamHasMember(org, teamSlug, user string) (bool, error)
+}
+
+// restrictedLabelsFor returns every RestrictedLabel entry that applies to
+// org/repo, merging the "*", org and "org/repo" scopes of cfg.
+// RestrictedLabels, most specific scope first.
+func restrictedLabelsFor(cfg *Configuration, org, repo string) []RestrictedLabel {
+	if cfg == nil {
+		return nil
+	}
+	var entries []RestrictedLabel
+	entries = append(entries, cfg.RestrictedLabels[fmt.Sprintf("%s/%s", org, repo)]...)
+	entries = append(entries, cfg.RestrictedLabels[org]...)
+	entries = append(entries, cfg.RestrictedLabels["*"]...)
+	return entries
+}
+
+// IsLabelAllowed reports whether user is allowed to add or remove label on
+// org/repo. A label with no matching RestrictedLabel entry is unrestricted
+// and always allowed.
+func IsLabelAllowed(cfg *Configuration, gc teamChecker, org, repo, label, user string) (bool, error) {
+	for _, rl := range restrictedLabelsFor(cfg, org, repo) {
+		if rl.Label != label {
+			continue
+		}
+
+		for _, allowed := range rl.AllowedUsers {
+			if allowed == user {
+				return true, nil
+			}
+		}
+
+		for _, team := range rl.AllowedTeams {
+			isMember, err := gc.TeamHasMember(org, team, user)
+			if err != nil {
+				return false, fmt.Errorf("failed to check membership of team %q for user %q: %v", team, user, err)
+			}
+			if isMember {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	// No restriction configured for this label: anyone may apply it.
+	return true, nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import "testing"
+
+type fakeTeamChecker struct {
+	members map[string]map[string]bool // team -> user -> isMember
+}
+
+func (f *fakeTeamChecker) TeamHasMember(org, teamSlug, user string) (bool, error) {
+	return f.members[teamSlug][user], nil
+}
+
+func TestIsLabelAllowed(t *testing.T) {
+	cfg := &Configuration{
+		RestrictedLabels: map[string][]RestrictedLabel{
+			"org/repo": {
+				{Label: "priority/critical", AllowedUsers: []string{"alice"}, AllowedTeams: []string{"leads"}},
+			},
+		},
+	}
+	gc := &fakeTeamChecker{members: map[string]map[string]bool{
+		"leads": {"bob": true},
+	}}
+
+	cases := []struct {
+		name  string
+		label string
+		user  string
+		want  bool
+	}{
+		{name: "allowed user", label: "priority/critical", user: "alice", want: true},
+		{name: "denied user", label: "priority/critical", user: "mallory", want: false},
+		{name: "team-based allow", label: "priority/critical", user: "bob", want: true},
+		{name: "unrestricted label passthrough", label: "kind/bug", user: "mallory", want: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := IsLabelAllowed(cfg, gc, "org", "repo", tc.label, tc.user)
+			if err != nil {
+				t.Fatalf("IsLabelAllowed returned error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("IsLabelAllowed(%q, %q) = %v, want %v", tc.label, tc.user, got, tc.want)
+			}
+		})
+	}
+}